@@ -0,0 +1,203 @@
+// Package arc implements an Adaptive Replacement Cache, as described in
+// "ARC: A Self-Tuning, Low Overhead Replacement Cache" (Megiddo & Modha).
+// Its Set/Get/Has/Size methods are named like lru.Cache's, but it is not
+// generic: keys are strings, values are interface{}, and Set returns
+// nothing, so it is not a drop-in replacement for lru.Cache[K, V]. It
+// tracks two recency/frequency lists (T1, T2) plus two
+// ghost lists (B1, B2) of recently-evicted keys, and adapts the split
+// between them based on which ghost list is hit.
+package arc
+
+import "fmt"
+
+type entry struct {
+	key        string
+	value      interface{}
+	list       *list
+	next, prev *entry
+}
+
+func (e *entry) String() string {
+	return fmt.Sprintf("entry[%q: %v]", e.key, e.value)
+}
+
+// list is a plain doubly-linked list, used for each of T1, T2, B1 and B2.
+type list struct {
+	head, tail *entry
+	size       int
+}
+
+func (l *list) pushFront(e *entry) {
+	e.list = l
+	e.prev = nil
+	e.next = l.head
+	if l.head != nil {
+		l.head.prev = e
+	}
+	l.head = e
+	if l.tail == nil {
+		l.tail = e
+	}
+	l.size++
+}
+
+func (l *list) remove(e *entry) {
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		l.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		l.tail = e.prev
+	}
+	e.next, e.prev, e.list = nil, nil, nil
+	l.size--
+}
+
+func (l *list) popBack() *entry {
+	e := l.tail
+	if e == nil {
+		return nil
+	}
+	l.remove(e)
+	return e
+}
+
+// Cache is an Adaptive Replacement Cache of the given total capacity c.
+// |T1|+|T2| <= c, |T1|+|B1| <= c, and |T1|+|T2|+|B1|+|B2| <= 2c at all
+// times.
+type Cache struct {
+	cap int
+	p   int // target size of T1, adapted on ghost hits
+
+	t1, t2 *list // T1: seen once; T2: seen at least twice
+	b1, b2 *list // ghost lists: keys evicted from T1/T2, values discarded
+
+	entries map[string]*entry
+}
+
+func New(cap int) *Cache {
+	return &Cache{
+		cap:     cap,
+		t1:      &list{},
+		t2:      &list{},
+		b1:      &list{},
+		b2:      &list{},
+		entries: make(map[string]*entry),
+	}
+}
+
+func (c *Cache) Set(key string, value interface{}) {
+	if c.cap <= 0 {
+		// A non-positive capacity cache never retains anything: every one
+		// of the eviction branches below assumes at least one list is
+		// non-empty, which doesn't hold when nothing is allowed in.
+		return
+	}
+
+	if e, ok := c.entries[key]; ok {
+		switch e.list {
+		case c.t1, c.t2:
+			e.value = value
+			e.list.remove(e)
+			c.t2.pushFront(e)
+			return
+		case c.b1:
+			c.p = min(c.cap, c.p+adaptDelta(c.b2.size, c.b1.size))
+			c.replace(false)
+			e.list.remove(e)
+			e.value = value
+			c.t2.pushFront(e)
+			return
+		case c.b2:
+			c.p = max(0, c.p-adaptDelta(c.b1.size, c.b2.size))
+			c.replace(true)
+			e.list.remove(e)
+			e.value = value
+			c.t2.pushFront(e)
+			return
+		}
+	}
+
+	if c.t1.size+c.b1.size == c.cap {
+		if c.t1.size < c.cap {
+			delete(c.entries, c.b1.popBack().key)
+			c.replace(false)
+		} else {
+			delete(c.entries, c.t1.popBack().key)
+		}
+	} else if total := c.t1.size + c.t2.size + c.b1.size + c.b2.size; total >= c.cap {
+		if total >= 2*c.cap {
+			delete(c.entries, c.b2.popBack().key)
+		}
+		c.replace(false)
+	}
+
+	e := &entry{key: key, value: value}
+	c.t1.pushFront(e)
+	c.entries[key] = e
+}
+
+func (c *Cache) Get(key string) (interface{}, bool) {
+	e, ok := c.entries[key]
+	if !ok || (e.list != c.t1 && e.list != c.t2) {
+		return nil, false
+	}
+	value := e.value
+	e.list.remove(e)
+	c.t2.pushFront(e)
+	return value, true
+}
+
+func (c *Cache) Has(key string) bool {
+	e, ok := c.entries[key]
+	return ok && (e.list == c.t1 || e.list == c.t2)
+}
+
+func (c *Cache) Size() int {
+	return c.t1.size + c.t2.size
+}
+
+// replace evicts the LRU entry of T1 or T2 into the corresponding ghost
+// list, per the ARC paper's REPLACE procedure. keyInB2 indicates whether
+// the key that triggered this replacement is a hit in B2, which biases the
+// choice towards evicting from T1 when |T1| == p.
+func (c *Cache) replace(keyInB2 bool) {
+	if c.t1.size > 0 && (c.t1.size > c.p || (keyInB2 && c.t1.size == c.p)) {
+		e := c.t1.popBack()
+		e.value = nil
+		c.b1.pushFront(e)
+		return
+	}
+	if c.t2.size > 0 {
+		e := c.t2.popBack()
+		e.value = nil
+		c.b2.pushFront(e)
+	}
+}
+
+// adaptDelta computes max(num/den, 1), the adjustment the ARC paper applies
+// to p on a ghost hit; den is the size of the ghost list that was hit, so it
+// is always >= 1 when this is called.
+func adaptDelta(num, den int) int {
+	if den == 0 {
+		return 1
+	}
+	return max(num/den, 1)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
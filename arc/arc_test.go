@@ -0,0 +1,184 @@
+package arc
+
+import "testing"
+
+func TestCache_BasicSetGet(t *testing.T) {
+	c := New(5)
+	c.Set("hello", "world")
+	val, ok := c.Get("hello")
+	if !ok {
+		t.Fatalf("cache returned !ok for key %q", "hello")
+	}
+	if val != "world" {
+		t.Errorf("got value %q for key %q, wanted %q", val, "hello", "world")
+	}
+}
+
+func TestCache_MissingValue(t *testing.T) {
+	c := New(5)
+	assertNotHas(t, c, "foo")
+}
+
+func TestCache_ZeroCapacityNeverRetains(t *testing.T) {
+	c := New(0)
+	set(t, c, "a") // must not panic
+	assertNotHas(t, c, "a")
+}
+
+func TestCache_ReachesCapacity(t *testing.T) {
+	c := New(5)
+	set(t, c, "a")
+	set(t, c, "b")
+	set(t, c, "c")
+	set(t, c, "d")
+	set(t, c, "e")
+
+	assertHas(t, c, "a")
+	assertHas(t, c, "b")
+	assertHas(t, c, "c")
+	assertHas(t, c, "d")
+	assertHas(t, c, "e")
+}
+
+func TestCache_ExceedsCapacity(t *testing.T) {
+	c := New(3)
+	set(t, c, "a")
+	set(t, c, "b")
+	set(t, c, "c")
+	set(t, c, "d")
+
+	// 'a' was only ever in T1, and with T1 full and B1 empty this is ARC's
+	// Case IV(A): 'a' is discarded outright rather than moved into a ghost list.
+	assertNotHas(t, c, "a")
+
+	assertHas(t, c, "b")
+	assertHas(t, c, "c")
+	assertHas(t, c, "d")
+}
+
+func TestCache_PromotesOnSecondAccess(t *testing.T) {
+	c := New(3)
+	set(t, c, "a")
+	if c.entries["a"].list != c.t1 {
+		t.Fatalf("key %q should start in T1", "a")
+	}
+
+	c.Get("a")
+	if c.entries["a"].list != c.t2 {
+		t.Fatalf("key %q should move to T2 after a second access", "a")
+	}
+}
+
+func TestCache_GhostHitAdaptsP(t *testing.T) {
+	c := New(2)
+	set(t, c, "a")
+	set(t, c, "b")
+	assertHas(t, c, "a") // promotes 'a' to T2, so the next eviction takes 'b' (T1's LRU) into B1, not 'a'
+	set(t, c, "c")       // T1+B1 < cap but T1+T2 == cap: REPLACE moves 'b' from T1 into B1
+
+	if _, ok := c.entries["b"]; !ok {
+		t.Fatalf("expected evicted key %q to remain in a ghost list", "b")
+	}
+	if c.entries["b"].list != c.b1 {
+		t.Fatalf("expected evicted key %q to land in B1", "b")
+	}
+
+	before := c.p
+	set(t, c, "b") // hit in B1, should grow p and promote 'b' into T2
+	if c.p <= before {
+		t.Errorf("expected p to grow on a B1 hit: before=%d after=%d", before, c.p)
+	}
+	if c.entries["b"].list != c.t2 {
+		t.Fatalf("key %q should be promoted to T2 on a ghost hit", "b")
+	}
+}
+
+func set(t *testing.T, c *Cache, k string) {
+	t.Helper()
+	c.Set(k, k)
+	assertValid(t, c)
+}
+
+func assertHas(t *testing.T, c *Cache, k string) {
+	t.Helper()
+	if !c.Has(k) {
+		t.Errorf("cache does not contain key %q", k)
+		return
+	}
+	val, ok := c.Get(k)
+	if !ok {
+		t.Errorf("Get(%q) returned !ok after .Has returned true", k)
+		return
+	}
+	if val != k {
+		t.Errorf("got value %q for key %q; expecting %q", val, k, k)
+	}
+	assertValid(t, c)
+}
+
+func assertNotHas(t *testing.T, c *Cache, k string) {
+	t.Helper()
+	if c.Has(k) {
+		t.Errorf("cache has key %q; should not", k)
+		return
+	}
+	val, ok := c.Get(k)
+	if val != nil {
+		t.Errorf("got non-nil value from non-existent key %q", k)
+	}
+	if ok {
+		t.Errorf("Get(%q) returned ok after .Has returned false", k)
+	}
+	assertValid(t, c)
+}
+
+// assertValid checks the ARC list-size invariants from the paper, plus
+// basic linked-list consistency for each of T1, T2, B1 and B2.
+func assertValid(t *testing.T, c *Cache) {
+	t.Helper()
+
+	if got := c.t1.size + c.t2.size; got > c.cap {
+		t.Fatalf("|T1|+|T2| = %d exceeds capacity %d", got, c.cap)
+	}
+	if got := c.t1.size + c.b1.size; got > c.cap {
+		t.Fatalf("|T1|+|B1| = %d exceeds capacity %d", got, c.cap)
+	}
+	if got := c.t1.size + c.t2.size + c.b1.size + c.b2.size; got > 2*c.cap {
+		t.Fatalf("|T1|+|T2|+|B1|+|B2| = %d exceeds 2*capacity %d", got, 2*c.cap)
+	}
+
+	assertListValid(t, c.t1)
+	assertListValid(t, c.t2)
+	assertListValid(t, c.b1)
+	assertListValid(t, c.b2)
+}
+
+func assertListValid(t *testing.T, l *list) {
+	t.Helper()
+	if l.head == nil {
+		if l.size != 0 {
+			t.Fatalf("empty list reports size %d", l.size)
+		}
+		return
+	}
+	seen := make(map[*entry]struct{})
+	n := 0
+	var a, b *entry
+	for a, b = l.head, l.head.next; b != nil; a, b = b, b.next {
+		if _, ok := seen[a]; ok {
+			t.Fatalf("cycle in list at node %v", a)
+		}
+		seen[a] = struct{}{}
+		n++
+		if b.prev != a {
+			t.Fatalf("%v does not point back to %v", b, a)
+		}
+	}
+	n++
+	if a != l.tail {
+		t.Fatalf("last seen element (%v) is not the tail (%v)", a, l.tail)
+	}
+	if n != l.size {
+		t.Fatalf("list reports size %d but has %d entries", l.size, n)
+	}
+}
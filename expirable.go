@@ -0,0 +1,117 @@
+package lru
+
+import (
+	"container/heap"
+	"time"
+)
+
+// ttlItem is an entry in a Cache's expiration heap: a key and the deadline
+// it was scheduled to expire at. A key may appear more than once in the
+// heap if it was re-set with a new TTL before its old one fired; sweep
+// discards any item whose deadline no longer matches the cache's current
+// record for that key.
+type ttlItem[K comparable] struct {
+	key      K
+	deadline time.Time
+}
+
+// ttlHeap is a container/heap min-heap ordered by deadline, so the janitor
+// can always pop the next key to expire in O(log n) rather than scanning
+// every entry.
+type ttlHeap[K comparable] []ttlItem[K]
+
+func (h ttlHeap[K]) Len() int            { return len(h) }
+func (h ttlHeap[K]) Less(i, j int) bool  { return h[i].deadline.Before(h[j].deadline) }
+func (h ttlHeap[K]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *ttlHeap[K]) Push(x interface{}) { *h = append(*h, x.(ttlItem[K])) }
+func (h *ttlHeap[K]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// WithDefaultTTL is the Option form of NewExpirable's defaultTTL, for
+// composing a default expiration with other Cache options. It does not, by
+// itself, start a janitor goroutine; use NewExpirable for that.
+func WithDefaultTTL[K comparable, V any](defaultTTL time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.defaultTTL = defaultTTL
+	}
+}
+
+// NewExpirable returns a Cache whose entries expire defaultTTL after being
+// set (lazily, on the next Get/Has of that key) and are also proactively swept by a
+// background janitor goroutine that wakes every sweepInterval. Callers must
+// call Close to stop the janitor once the cache is no longer needed.
+func NewExpirable[K comparable, V any](cap int, defaultTTL, sweepInterval time.Duration) *Cache[K, V] {
+	c := NewWithOptions[K, V](cap, WithDefaultTTL[K, V](defaultTTL))
+	c.done = make(chan struct{})
+	go c.runJanitor(sweepInterval)
+	return c
+}
+
+// Close stops the background janitor goroutine started by NewExpirable. It
+// is a no-op for caches without one.
+func (c *Cache[K, V]) Close() {
+	if c.done != nil {
+		close(c.done)
+	}
+}
+
+func (c *Cache[K, V]) runJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.sweep()
+		}
+	}
+}
+
+// sweep pops and removes every expired entry from the head of the
+// expiration heap, stopping as soon as it finds one that hasn't expired
+// yet (everything behind it expires later still).
+func (c *Cache[K, V]) sweep() {
+	c.mu.Lock()
+	var pending []notification[K, V]
+
+	now := time.Now()
+	for c.expHeap != nil && c.expHeap.Len() > 0 {
+		next := (*c.expHeap)[0]
+		if next.deadline.After(now) {
+			break
+		}
+		heap.Pop(c.expHeap)
+
+		if deadline, ok := c.deadlines[next.key]; !ok || !deadline.Equal(next.deadline) {
+			continue // stale: key was updated or removed since this was scheduled
+		}
+		c.expireLocked(next.key, &pending)
+	}
+
+	c.mu.Unlock()
+	c.fire(pending)
+}
+
+func (c *Cache[K, V]) setDeadlineLocked(key K, ttl time.Duration) {
+	if c.deadlines == nil {
+		c.deadlines = make(map[K]time.Time)
+	}
+	if c.expHeap == nil {
+		c.expHeap = &ttlHeap[K]{}
+	}
+
+	deadline := time.Now().Add(ttl)
+	c.deadlines[key] = deadline
+	heap.Push(c.expHeap, ttlItem[K]{key: key, deadline: deadline})
+}
+
+func (c *Cache[K, V]) expiredLocked(key K) bool {
+	deadline, ok := c.deadlines[key]
+	return ok && time.Now().After(deadline)
+}
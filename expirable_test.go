@@ -0,0 +1,89 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache_SetWithTTLLazyExpiration(t *testing.T) {
+	c := New[string, string](5)
+	c.SetWithTTL("a", "1", 10*time.Millisecond)
+
+	if !c.Has("a") {
+		t.Fatalf("expected %q to be present before its TTL elapses", "a")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if c.Has("a") {
+		t.Errorf("expected %q to be expired", "a")
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get(%q) returned ok for an expired key", "a")
+	}
+}
+
+func TestCache_DefaultTTL(t *testing.T) {
+	c := NewWithOptions[string, string](5, WithDefaultTTL[string, string](10*time.Millisecond))
+	c.Set("a", "1")
+
+	time.Sleep(20 * time.Millisecond)
+
+	if c.Has("a") {
+		t.Errorf("expected %q to expire under the cache's default TTL", "a")
+	}
+}
+
+func TestCache_ExpirableJanitorSweeps(t *testing.T) {
+	c := NewExpirable[string, string](5, 10*time.Millisecond, 5*time.Millisecond)
+	defer c.Close()
+
+	c.Set("a", "1")
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		_, stillTracked := c.values["a"]
+		c.mu.Unlock()
+		if !stillTracked {
+			return // swept by the janitor, as expected
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected the janitor to proactively remove expired key %q", "a")
+}
+
+func TestCache_DefaultTTLSkipsRejectedCharge(t *testing.T) {
+	charge := func(key, value string) int64 { return int64(len(value)) }
+	var evicted []string
+	c := NewWithOptions[string, string](10,
+		WithCharge[string, string](2, charge),
+		WithDefaultTTL[string, string](10*time.Millisecond),
+		WithOnEvict[string, string](func(key, value string) {
+			evicted = append(evicted, key)
+		}),
+	)
+
+	c.Set("a", "hello") // charge 5 > max charge 2; rejected outright
+	if c.Has("a") {
+		t.Fatalf("expected oversized entry %q to be rejected", "a")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	c.Has("a") // lazy-expiry check; must not fire OnEvict for a key that was never admitted
+
+	if len(evicted) != 0 {
+		t.Fatalf("OnEvict fired for %v, wanted none (key was never admitted)", evicted)
+	}
+}
+
+func TestCache_SetWithTTLOverridesDefault(t *testing.T) {
+	c := NewWithOptions[string, string](5, WithDefaultTTL[string, string](time.Hour))
+	c.SetWithTTL("a", "1", 10*time.Millisecond)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if c.Has("a") {
+		t.Errorf("expected %q's explicit TTL to override the cache's default", "a")
+	}
+}
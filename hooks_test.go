@@ -0,0 +1,74 @@
+package lru
+
+import "testing"
+
+func TestCache_Remove(t *testing.T) {
+	c := New[string, string](5)
+	c.Set("a", "1")
+
+	if !c.Remove("a") {
+		t.Fatalf("Remove(%q) = false, wanted true", "a")
+	}
+	if c.Has("a") {
+		t.Errorf("key %q still present after Remove", "a")
+	}
+	if c.Remove("a") {
+		t.Errorf("Remove(%q) = true for an already-removed key", "a")
+	}
+}
+
+func TestCache_OnRemoveFiresOnExplicitRemove(t *testing.T) {
+	var removed []string
+	c := NewWithOptions[string, string](5, WithOnRemove[string, string](func(key, value string) {
+		removed = append(removed, key)
+	}))
+	c.Set("a", "1")
+	c.Remove("a")
+
+	if len(removed) != 1 || removed[0] != "a" {
+		t.Fatalf("OnRemove fired for %v, wanted [a]", removed)
+	}
+}
+
+func TestCache_OnEvictFiresOnCapacityEviction(t *testing.T) {
+	var evicted []string
+	c := NewWithOptions[string, string](2, WithOnEvict[string, string](func(key, value string) {
+		evicted = append(evicted, key)
+	}))
+	c.Set("a", "1")
+	c.Set("b", "2")
+	c.Set("c", "3") // evicts 'a'
+
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("OnEvict fired for %v, wanted [a]", evicted)
+	}
+}
+
+func TestCache_OnEvictDoesNotFireOnExplicitRemove(t *testing.T) {
+	var evicted []string
+	c := NewWithOptions[string, string](5, WithOnEvict[string, string](func(key, value string) {
+		evicted = append(evicted, key)
+	}))
+	c.Set("a", "1")
+	c.Remove("a")
+
+	if len(evicted) != 0 {
+		t.Fatalf("OnEvict fired for %v, wanted none", evicted)
+	}
+}
+
+func TestCache_OnEvictCanReinsert(t *testing.T) {
+	var c *Cache[string, string]
+	c = NewWithOptions[string, string](2, WithOnEvict[string, string](func(key, value string) {
+		if key == "a" {
+			c.Set("a", "requeued") // must not deadlock: runs after the lock is released
+		}
+	}))
+	c.Set("a", "1")
+	c.Set("b", "2")
+	c.Set("c", "3") // evicts 'a', whose callback re-Sets it
+
+	if !c.Has("a") {
+		t.Fatalf("expected %q to have been re-inserted by its own OnEvict callback", "a")
+	}
+}
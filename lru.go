@@ -1,123 +1,332 @@
 package lru
 
 import (
-	"fmt"
-	"strings"
+	"math"
+	"sync"
+	"time"
+
+	lrupolicy "github.com/alecbz/go-lru/policy/lru"
 )
 
-type Cache struct {
-	head, tail *entry
-	entries    map[string]*entry
-	cap        int
+// Policy decides which key to evict as a Cache grows past capacity. The
+// recency/frequency bookkeeping that used to live directly on Cache is
+// factored out behind this interface so callers can plug in alternative
+// eviction strategies (see the policy/lru, policy/lfu and policy/fifo
+// subpackages) via NewWithPolicy.
+type Policy[K comparable] interface {
+	// Touch records an access to key, which is already present in the cache.
+	Touch(key K)
+	// Admit records the insertion of a brand new key, returning the key
+	// evicted to make room for it, if any.
+	Admit(key K) (evicted K, hadEvict bool)
+	// Remove forgets key, e.g. because the cache removed it directly.
+	Remove(key K)
+}
+
+// Evictor is implemented by policies that can give up their least-wanted
+// key on demand, rather than only as a side effect of Admit. Charge-bounded
+// caches (see NewWithCharge) use it to reclaim more than one entry per Set.
+type Evictor[K comparable] interface {
+	Evict() (key K, ok bool)
+}
+
+type Cache[K comparable, V any] struct {
+	mu sync.Mutex
+
+	values map[K]V
+	policy Policy[K]
+
+	// Set by NewWithCharge/WithCharge; chargeFn == nil means the cache is
+	// bounded by entry count (via policy) rather than by charge.
+	maxCharge int64
+	chargeFn  func(key K, value V) int64
+	charge    int64
+
+	// Set by NewExpirable/WithDefaultTTL/SetWithTTL; see expirable.go.
+	defaultTTL time.Duration
+	deadlines  map[K]time.Time
+	expHeap    *ttlHeap[K]
+	done       chan struct{}
+
+	// Set by WithOnEvict/WithOnRemove.
+	onEvict  func(key K, value V)
+	onRemove func(key K, value V)
+}
+
+// New returns a Cache using the LRU policy, the same eviction behavior the
+// original non-generic Cache always had.
+func New[K comparable, V any](cap int) *Cache[K, V] {
+	return NewWithPolicy[K, V](cap, lrupolicy.New[K](cap))
 }
 
-func New(cap int) *Cache {
-	return &Cache{
-		entries: make(map[string]*entry),
-		cap:     cap,
+func NewWithPolicy[K comparable, V any](cap int, policy Policy[K]) *Cache[K, V] {
+	return &Cache[K, V]{
+		values: make(map[K]V),
+		policy: policy,
 	}
 }
 
-type entry struct {
-	key string
-	value interface{}
-	next, prev *entry
+// NewWithCharge returns a Cache bounded by total charge rather than entry
+// count: charge computes the weight of a key/value pair (e.g. its size in
+// bytes), and Set evicts least-recently-used entries until the running
+// total fits within maxCharge. An entry whose own charge exceeds maxCharge
+// is rejected outright rather than evicting everything else to admit it.
+func NewWithCharge[K comparable, V any](maxCharge int64, charge func(key K, value V) int64) *Cache[K, V] {
+	c := &Cache[K, V]{values: make(map[K]V)}
+	WithCharge(maxCharge, charge)(c)
+	return c
 }
 
-func (e *entry) String() string {
-	return fmt.Sprintf("entry[%q: %v]", e.key, e.value)
+// Option configures a Cache constructed via NewWithOptions.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithCharge is the Option form of NewWithCharge, for composing charge
+// bounds with other Cache options. It switches the cache to an unbounded
+// recency policy, since charge (not entry count) becomes the real limit.
+func WithCharge[K comparable, V any](maxCharge int64, charge func(key K, value V) int64) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.policy = lrupolicy.New[K](math.MaxInt)
+		c.maxCharge = maxCharge
+		c.chargeFn = charge
+	}
 }
 
-func (c *Cache) Set(key string, value interface{}) {
-	e, ok := c.entries[key]
-	if !ok {
-		e = &entry{key: key, value: value}
-		c.entries[key] = e
-	} else {
-		e.value = value
+func NewWithOptions[K comparable, V any](cap int, opts ...Option[K, V]) *Cache[K, V] {
+	c := New[K, V](cap)
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
+}
 
-	c.moveFront(e)
+// WithOnEvict sets a callback fired whenever a key is dropped from the
+// cache without being asked for: because the policy evicted it to make
+// room, or because it expired. The callback runs after the entry has been
+// unlinked from the policy and deleted from the cache, so it's safe for it
+// to re-Set the same key.
+func WithOnEvict[K comparable, V any](onEvict func(key K, value V)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvict = onEvict
+	}
+}
 
-	if len(c.entries) > c.cap {
-		c.pop()
+// WithOnRemove sets a callback fired whenever a caller explicitly removes a
+// key via Remove. Like WithOnEvict, it runs after the entry is fully gone
+// from the cache.
+func WithOnRemove[K comparable, V any](onRemove func(key K, value V)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onRemove = onRemove
 	}
 }
 
-func (c *Cache) Get(key string) (interface{}, bool) {
-	e, ok := c.entries[key]
-	if !ok {
-		return nil, false
+// notification is a deferred OnEvict/OnRemove call, queued up while the
+// mutex is held and fired only once it's released, so that callbacks are
+// free to call back into the Cache (e.g. to re-Set the evicted key).
+type notification[K comparable, V any] struct {
+	key   K
+	value V
+	evict bool // true: fire onEvict; false: fire onRemove
+}
+
+func (c *Cache[K, V]) fire(pending []notification[K, V]) {
+	for _, n := range pending {
+		if n.evict {
+			if c.onEvict != nil {
+				c.onEvict(n.key, n.value)
+			}
+		} else if c.onRemove != nil {
+			c.onRemove(n.key, n.value)
+		}
 	}
-	c.moveFront(e)
-	return e.value, true
 }
 
-func (c *Cache) Has(key string) bool {
-	_, ok := c.entries[key]
-	return ok
+// Set stores key/value, reporting whether it was actually admitted to the
+// cache. It is always true except for a charge-bounded cache (see
+// NewWithCharge) asked to store an entry whose own charge exceeds the
+// cache's maximum, which is rejected outright rather than evicting
+// everything else to make room for it.
+func (c *Cache[K, V]) Set(key K, value V) bool {
+	c.mu.Lock()
+	var pending []notification[K, V]
+	admitted := c.setLocked(key, value, &pending)
+	if admitted && c.defaultTTL > 0 {
+		c.setDeadlineLocked(key, c.defaultTTL)
+	}
+	c.mu.Unlock()
+
+	c.fire(pending)
+	return admitted
 }
 
+// SetWithTTL is like Set, but key expires after ttl regardless of the
+// cache's default TTL (if any). See expirable.go.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) bool {
+	c.mu.Lock()
+	var pending []notification[K, V]
+	admitted := c.setLocked(key, value, &pending)
+	if admitted {
+		c.setDeadlineLocked(key, ttl)
+	}
+	c.mu.Unlock()
 
-func (c *Cache) Size() int {
-	return len(c.entries)
+	c.fire(pending)
+	return admitted
 }
 
-// Used to test internal validity of the cache's linked list. Useful in debugging implementation changes.
+// setLocked stores key/value and reports whether it was actually admitted
+// to the cache: false means a charge-bounded cache rejected it for being
+// too large on its own, in which case callers must not treat key as cached
+// (e.g. by scheduling a TTL for it).
+func (c *Cache[K, V]) setLocked(key K, value V, pending *[]notification[K, V]) bool {
+	if c.chargeFn != nil {
+		return c.setChargedLocked(key, value, pending)
+	}
 
-func (c *Cache) String() string {
-	if c.head == nil {
-		return fmt.Sprint(c.head)
+	_, existed := c.values[key]
+	c.values[key] = value
+	if existed {
+		c.policy.Touch(key)
+		return true
 	}
-	var parts []string
-	seen := make(map[*entry]struct{})
-	for e := c.head; e != nil; e = e.next {
-		if _, ok := seen[e]; ok {
-			panic("cycle in cache linked list")
-		}
-		seen[e] = struct{}{}
-		parts = append(parts, e.String())
+
+	if evicted, ok := c.policy.Admit(key); ok {
+		c.evictLocked(evicted, pending)
 	}
-	return strings.Join(parts, " -> ")
+	return true
 }
 
-func (c *Cache) moveFront(e *entry) {
-	if e == c.head {
-		return
+func (c *Cache[K, V]) setChargedLocked(key K, value V, pending *[]notification[K, V]) bool {
+	cost := c.chargeFn(key, value)
+	if cost > c.maxCharge {
+		return false
 	}
 
-	if e.next != nil {
-		e.next.prev = e.prev
+	if old, existed := c.values[key]; existed {
+		c.charge -= c.chargeFn(key, old)
+		c.values[key] = value
+		c.charge += cost
+		c.policy.Touch(key)
+	} else {
+		c.values[key] = value
+		c.charge += cost
+		c.policy.Admit(key)
 	}
-	if e.prev != nil {
-		e.prev.next = e.next
+
+	evictor := c.policy.(Evictor[K])
+	for c.charge > c.maxCharge {
+		evicted, ok := evictor.Evict()
+		if !ok {
+			break
+		}
+		c.charge -= c.chargeFn(evicted, c.values[evicted])
+		c.evictLocked(evicted, pending)
+	}
+	return true
+}
+
+// Charge returns the running total of the charge function over every entry
+// currently in the cache. It is always 0 for caches not constructed with a
+// charge bound.
+func (c *Cache[K, V]) Charge() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.charge
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	var pending []notification[K, V]
+
+	if c.expiredLocked(key) {
+		c.expireLocked(key, &pending)
+		c.mu.Unlock()
+		c.fire(pending)
+		var zero V
+		return zero, false
 	}
 
-	if e == c.tail {
-		// Update the tail if we're moving the tail to the front
-		c.tail = e.prev
+	value, ok := c.values[key]
+	if ok {
+		c.policy.Touch(key)
 	}
+	c.mu.Unlock()
 
-	e.prev = nil
-	e.next = c.head
-	if c.head != nil {
-		c.head.prev = e
+	if !ok {
+		var zero V
+		return zero, false
 	}
-	c.head = e
+	return value, true
+}
+
+func (c *Cache[K, V]) Has(key K) bool {
+	c.mu.Lock()
+	var pending []notification[K, V]
 
-	// Initialize the tail if we don't have one:
-	if c.tail == nil {
-		c.tail = e
+	if c.expiredLocked(key) {
+		c.expireLocked(key, &pending)
+		c.mu.Unlock()
+		c.fire(pending)
+		return false
 	}
+
+	_, ok := c.values[key]
+	c.mu.Unlock()
+	return ok
+}
+
+func (c *Cache[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.values)
 }
 
-func (c *Cache) pop() {
-	if c.tail == nil {
-		panic("pop called with no tail")
+// Remove drops key from the cache, firing OnRemove (if set) and reporting
+// whether the key was present.
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	value, ok := c.values[key]
+	if !ok {
+		c.mu.Unlock()
+		return false
+	}
+	c.policy.Remove(key)
+	if c.chargeFn != nil {
+		c.charge -= c.chargeFn(key, value)
+	}
+	c.forgetLocked(key)
+	c.mu.Unlock()
+
+	if c.onRemove != nil {
+		c.onRemove(key, value)
 	}
-	if c.tail.prev != nil {
-		c.tail.prev.next = nil
+	return true
+}
+
+// forgetLocked drops key from the value and deadline maps, without telling
+// the policy or queuing any callback.
+func (c *Cache[K, V]) forgetLocked(key K) {
+	delete(c.values, key)
+	delete(c.deadlines, key)
+}
+
+// evictLocked forgets key, which the policy has already unlinked (it chose
+// to evict it, e.g. from Admit), and queues OnEvict to fire once the mutex
+// is released.
+func (c *Cache[K, V]) evictLocked(key K, pending *[]notification[K, V]) {
+	value := c.values[key]
+	c.forgetLocked(key)
+	*pending = append(*pending, notification[K, V]{key: key, value: value, evict: true})
+}
+
+// expireLocked forgets key, which the policy still thinks is live, because
+// it expired rather than being evicted or removed. Like evictLocked, it
+// queues OnEvict rather than firing it directly.
+func (c *Cache[K, V]) expireLocked(key K, pending *[]notification[K, V]) {
+	value := c.values[key]
+	c.policy.Remove(key)
+	if c.chargeFn != nil {
+		c.charge -= c.chargeFn(key, value)
 	}
-	delete(c.entries, c.tail.key)
-	c.tail = c.tail.prev
-}
\ No newline at end of file
+	c.forgetLocked(key)
+	*pending = append(*pending, notification[K, V]{key: key, value: value, evict: true})
+}
@@ -0,0 +1,74 @@
+// Package fifo implements lru.Policy: eviction of the oldest-inserted key
+// once the cache grows past its capacity, ignoring access order entirely.
+package fifo
+
+type node[K comparable] struct {
+	key        K
+	next, prev *node[K]
+}
+
+// Policy is an insertion-order queue: Admit appends to the back and evicts
+// from the front once the queue grows past cap. Touch is a no-op, since
+// FIFO eviction doesn't depend on access recency.
+type Policy[K comparable] struct {
+	cap        int
+	head, tail *node[K] // head is oldest, tail is newest
+	nodes      map[K]*node[K]
+}
+
+func New[K comparable](cap int) *Policy[K] {
+	return &Policy[K]{
+		cap:   cap,
+		nodes: make(map[K]*node[K]),
+	}
+}
+
+func (p *Policy[K]) Touch(key K) {}
+
+func (p *Policy[K]) Admit(key K) (evicted K, hadEvict bool) {
+	n := &node[K]{key: key, prev: p.tail}
+	if p.tail != nil {
+		p.tail.next = n
+	}
+	p.tail = n
+	if p.head == nil {
+		p.head = n
+	}
+	p.nodes[key] = n
+
+	if len(p.nodes) > p.cap {
+		evicted, hadEvict = p.pop(), true
+	}
+	return evicted, hadEvict
+}
+
+func (p *Policy[K]) Remove(key K) {
+	n, ok := p.nodes[key]
+	if !ok {
+		return
+	}
+	p.unlink(n)
+	delete(p.nodes, key)
+}
+
+func (p *Policy[K]) unlink(n *node[K]) {
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+	if n.prev != nil {
+		n.prev.next = n.next
+	}
+	if n == p.head {
+		p.head = n.next
+	}
+	if n == p.tail {
+		p.tail = n.prev
+	}
+}
+
+func (p *Policy[K]) pop() K {
+	key := p.head.key
+	p.unlink(p.head)
+	delete(p.nodes, key)
+	return key
+}
@@ -0,0 +1,156 @@
+// Package lfu implements lru.Policy: eviction of the least-frequently-used
+// key once the cache grows past its capacity, ties broken by recency. It
+// uses the classic O(1) design: a doubly-linked list of frequency buckets,
+// each holding a doubly-linked list of the keys at that frequency, so
+// Touch/Admit/Remove never need to scan.
+package lfu
+
+type item[K comparable] struct {
+	key        K
+	freq       *freq[K]
+	next, prev *item[K] // position within freq.items, MRU-first
+}
+
+type freq[K comparable] struct {
+	count      int
+	items      *item[K] // head of this bucket's item list (MRU-first); nil if empty
+	tail       *item[K] // tail of this bucket's item list (LRU); nil if empty
+	next, prev *freq[K] // position within the ascending freq list
+}
+
+// Policy buckets keys by access count: min is the bucket with the lowest
+// count, and the freq list is kept in strictly ascending order so eviction
+// always pops the tail item of min.
+type Policy[K comparable] struct {
+	cap   int
+	min   *freq[K]
+	nodes map[K]*item[K]
+}
+
+func New[K comparable](cap int) *Policy[K] {
+	return &Policy[K]{
+		cap:   cap,
+		nodes: make(map[K]*item[K]),
+	}
+}
+
+func (p *Policy[K]) Touch(key K) {
+	p.bump(p.nodes[key])
+}
+
+func (p *Policy[K]) Admit(key K) (evicted K, hadEvict bool) {
+	// Evict before inserting, so the new key (which starts at freq 1) is
+	// never itself the one chosen to make room.
+	if len(p.nodes) > 0 && len(p.nodes) >= p.cap {
+		evicted, hadEvict = p.pop(), true
+	}
+
+	it := &item[K]{key: key}
+	p.nodes[key] = it
+	p.insertIntoBucket(it, p.bucket(1, nil))
+
+	if len(p.nodes) > p.cap {
+		evicted, hadEvict = p.pop(), true
+	}
+	return evicted, hadEvict
+}
+
+func (p *Policy[K]) Remove(key K) {
+	it, ok := p.nodes[key]
+	if !ok {
+		return
+	}
+	p.removeFromBucket(it)
+	delete(p.nodes, key)
+}
+
+// bump moves it from its current bucket to the bucket for count+1,
+// creating that bucket if it doesn't already exist.
+func (p *Policy[K]) bump(it *item[K]) {
+	f := it.freq
+	next := p.bucket(f.count+1, f)
+	p.removeFromBucket(it)
+	p.insertIntoBucket(it, next)
+}
+
+// bucket returns the freq node for the given count, creating and linking
+// one immediately after `after` (or at the head, if after is nil) if it
+// doesn't already exist.
+func (p *Policy[K]) bucket(count int, after *freq[K]) *freq[K] {
+	if after == nil {
+		if p.min != nil && p.min.count == count {
+			return p.min
+		}
+	} else if after.next != nil && after.next.count == count {
+		return after.next
+	}
+
+	f := &freq[K]{count: count}
+	if after == nil {
+		f.next = p.min
+		if p.min != nil {
+			p.min.prev = f
+		}
+		p.min = f
+	} else {
+		f.next = after.next
+		f.prev = after
+		if after.next != nil {
+			after.next.prev = f
+		}
+		after.next = f
+	}
+	return f
+}
+
+func (p *Policy[K]) insertIntoBucket(it *item[K], f *freq[K]) {
+	it.freq = f
+	it.prev = nil
+	it.next = f.items
+	if f.items != nil {
+		f.items.prev = it
+	} else {
+		f.tail = it
+	}
+	f.items = it
+}
+
+func (p *Policy[K]) removeFromBucket(it *item[K]) {
+	f := it.freq
+	if it.prev != nil {
+		it.prev.next = it.next
+	} else {
+		f.items = it.next
+	}
+	if it.next != nil {
+		it.next.prev = it.prev
+	} else {
+		f.tail = it.prev
+	}
+	it.next, it.prev, it.freq = nil, nil, nil
+
+	if f.items == nil {
+		p.unlinkBucket(f)
+	}
+}
+
+func (p *Policy[K]) unlinkBucket(f *freq[K]) {
+	if f.prev != nil {
+		f.prev.next = f.next
+	} else {
+		p.min = f.next
+	}
+	if f.next != nil {
+		f.next.prev = f.prev
+	}
+}
+
+// pop evicts the least-recently-touched item in the lowest-frequency
+// bucket.
+func (p *Policy[K]) pop() K {
+	last := p.min.tail
+	key := last.key
+	p.removeFromBucket(last)
+	delete(p.nodes, key)
+	return key
+}
@@ -0,0 +1,60 @@
+package lfu
+
+import "testing"
+
+func TestPolicy_AdmitUnderCapacity(t *testing.T) {
+	p := New[string](3)
+	admit(t, p, "a")
+	admit(t, p, "b")
+	admit(t, p, "c")
+
+	if len(p.nodes) != 3 {
+		t.Fatalf("got %d tracked keys, wanted 3", len(p.nodes))
+	}
+}
+
+func TestPolicy_EvictsLeastFrequentlyUsed(t *testing.T) {
+	p := New[string](2)
+	admit(t, p, "a")
+	admit(t, p, "b")
+
+	p.Touch("a") // a: freq 2, b: freq 1
+
+	evicted, hadEvict := p.Admit("c")
+	if !hadEvict || evicted != "b" {
+		t.Fatalf("Admit(%q) = (%q, %v); wanted (%q, true)", "c", evicted, hadEvict, "b")
+	}
+}
+
+func TestPolicy_TiesBreakByRecency(t *testing.T) {
+	p := New[string](2)
+	admit(t, p, "a")
+	admit(t, p, "b") // both at freq 1; 'a' is the least-recently-touched
+
+	evicted, hadEvict := p.Admit("c")
+	if !hadEvict || evicted != "a" {
+		t.Fatalf("Admit(%q) = (%q, %v); wanted (%q, true)", "c", evicted, hadEvict, "a")
+	}
+}
+
+func TestPolicy_Remove(t *testing.T) {
+	p := New[string](2)
+	admit(t, p, "a")
+	admit(t, p, "b")
+
+	p.Remove("a")
+	if _, ok := p.nodes["a"]; ok {
+		t.Fatalf("key %q still tracked after Remove", "a")
+	}
+	if p.min == nil || p.min.items == nil {
+		t.Fatalf("expected %q to still be tracked in the min bucket", "b")
+	}
+
+	// removing an untracked key is a no-op
+	p.Remove("a")
+}
+
+func admit(t *testing.T, p *Policy[string], key string) {
+	t.Helper()
+	p.Admit(key)
+}
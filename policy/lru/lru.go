@@ -0,0 +1,98 @@
+// Package lru implements lru.Policy: eviction of the least-recently-used
+// key once the cache grows past its capacity. It is the policy used by
+// lru.New, factored out into its own package so it can be swapped for
+// policy/lfu or policy/fifo via lru.NewWithPolicy.
+package lru
+
+type node[K comparable] struct {
+	key        K
+	next, prev *node[K]
+}
+
+// Policy is a recency list: every Touch or Admit moves a key to the front,
+// and Admit evicts from the back once the list grows past cap.
+type Policy[K comparable] struct {
+	cap        int
+	head, tail *node[K]
+	nodes      map[K]*node[K]
+}
+
+func New[K comparable](cap int) *Policy[K] {
+	return &Policy[K]{
+		cap:   cap,
+		nodes: make(map[K]*node[K]),
+	}
+}
+
+func (p *Policy[K]) Touch(key K) {
+	p.moveFront(p.nodes[key])
+}
+
+func (p *Policy[K]) Admit(key K) (evicted K, hadEvict bool) {
+	n := &node[K]{key: key}
+	p.nodes[key] = n
+	p.moveFront(n)
+
+	if len(p.nodes) > p.cap {
+		evicted, hadEvict = p.pop(), true
+	}
+	return evicted, hadEvict
+}
+
+func (p *Policy[K]) Remove(key K) {
+	n, ok := p.nodes[key]
+	if !ok {
+		return
+	}
+	p.unlink(n)
+	delete(p.nodes, key)
+}
+
+// Evict forces eviction of the least-recently-used key, regardless of
+// whether the policy is over capacity. It implements lru.Evictor, which
+// charge-bounded caches use to reclaim more than one entry per Set.
+func (p *Policy[K]) Evict() (key K, ok bool) {
+	if p.tail == nil {
+		return key, false
+	}
+	return p.pop(), true
+}
+
+func (p *Policy[K]) moveFront(n *node[K]) {
+	if n == p.head {
+		return
+	}
+	p.unlink(n)
+
+	n.prev = nil
+	n.next = p.head
+	if p.head != nil {
+		p.head.prev = n
+	}
+	p.head = n
+	if p.tail == nil {
+		p.tail = n
+	}
+}
+
+func (p *Policy[K]) unlink(n *node[K]) {
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+	if n.prev != nil {
+		n.prev.next = n.next
+	}
+	if n == p.head {
+		p.head = n.next
+	}
+	if n == p.tail {
+		p.tail = n.prev
+	}
+}
+
+func (p *Policy[K]) pop() K {
+	key := p.tail.key
+	p.unlink(p.tail)
+	delete(p.nodes, key)
+	return key
+}
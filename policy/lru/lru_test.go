@@ -0,0 +1,80 @@
+package lru
+
+import "testing"
+
+func TestPolicy_AdmitUnderCapacity(t *testing.T) {
+	p := New[string](3)
+	admit(t, p, "a")
+	admit(t, p, "b")
+	admit(t, p, "c")
+
+	if len(p.nodes) != 3 {
+		t.Fatalf("got %d tracked keys, wanted 3", len(p.nodes))
+	}
+}
+
+func TestPolicy_AdmitEvictsOldest(t *testing.T) {
+	p := New[string](2)
+	admit(t, p, "a")
+	admit(t, p, "b")
+
+	evicted, hadEvict := p.Admit("c")
+	if !hadEvict || evicted != "a" {
+		t.Fatalf("Admit(%q) = (%q, %v); wanted (%q, true)", "c", evicted, hadEvict, "a")
+	}
+}
+
+func TestPolicy_TouchDelaysEviction(t *testing.T) {
+	p := New[string](2)
+	admit(t, p, "a")
+	admit(t, p, "b")
+
+	p.Touch("a") // now b is the oldest
+
+	evicted, hadEvict := p.Admit("c")
+	if !hadEvict || evicted != "b" {
+		t.Fatalf("Admit(%q) = (%q, %v); wanted (%q, true)", "c", evicted, hadEvict, "b")
+	}
+}
+
+func TestPolicy_Remove(t *testing.T) {
+	p := New[string](2)
+	admit(t, p, "a")
+	admit(t, p, "b")
+
+	p.Remove("a")
+	if _, ok := p.nodes["a"]; ok {
+		t.Fatalf("key %q still tracked after Remove", "a")
+	}
+	assertValid(t, p)
+
+	// removing an untracked key is a no-op
+	p.Remove("a")
+}
+
+func admit(t *testing.T, p *Policy[string], key string) {
+	t.Helper()
+	p.Admit(key)
+	assertValid(t, p)
+}
+
+func assertValid(t *testing.T, p *Policy[string]) {
+	t.Helper()
+	if p.head == nil {
+		return
+	}
+	seen := make(map[*node[string]]struct{})
+	var a, b *node[string]
+	for a, b = p.head, p.head.next; b != nil; a, b = b, b.next {
+		if _, ok := seen[a]; ok {
+			t.Fatalf("cycle in linked list at key %q", a.key)
+		}
+		seen[a] = struct{}{}
+		if b.prev != a {
+			t.Fatalf("%q does not point back to %q", b.key, a.key)
+		}
+	}
+	if a != p.tail {
+		t.Fatalf("last seen key (%q) is not the tail (%q)", a.key, p.tail.key)
+	}
+}
@@ -0,0 +1,83 @@
+package lru
+
+import "runtime"
+
+// ShardedCache partitions string keys across N independent Cache shards to
+// reduce lock contention under concurrent access: each shard already
+// guards itself with its own internal mutex (see Cache), so spreading keys
+// across shards means a Set/Get on one key never contends with one on a
+// key that hashes elsewhere.
+type ShardedCache[V any] struct {
+	shards []*Cache[string, V]
+	mask   uint64
+}
+
+// NewSharded returns a ShardedCache with roughly totalCap entries spread
+// evenly across shards shards. If shards <= 0, it defaults to
+// nextPow2(GOMAXPROCS*4). shards is always rounded up to a power of two, so
+// the shard lookup can mask instead of mod.
+func NewSharded[V any](totalCap, shards int) *ShardedCache[V] {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0) * 4
+	}
+	shards = nextPow2(shards)
+
+	perShard := (totalCap + shards - 1) / shards // ceil(totalCap/shards)
+	cs := make([]*Cache[string, V], shards)
+	for i := range cs {
+		cs[i] = New[string, V](perShard)
+	}
+
+	return &ShardedCache[V]{shards: cs, mask: uint64(shards - 1)}
+}
+
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// fnv64a is the FNV-1a hash, inlined here (rather than hash/fnv) so shard
+// lookup doesn't allocate a hash.Hash64 per call.
+func fnv64a(s string) uint64 {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+	h := uint64(offset)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime
+	}
+	return h
+}
+
+func (c *ShardedCache[V]) shardFor(key string) *Cache[string, V] {
+	return c.shards[fnv64a(key)&c.mask]
+}
+
+func (c *ShardedCache[V]) Set(key string, value V) {
+	c.shardFor(key).Set(key, value)
+}
+
+func (c *ShardedCache[V]) Get(key string) (V, bool) {
+	return c.shardFor(key).Get(key)
+}
+
+func (c *ShardedCache[V]) Has(key string) bool {
+	return c.shardFor(key).Has(key)
+}
+
+func (c *ShardedCache[V]) Remove(key string) bool {
+	return c.shardFor(key).Remove(key)
+}
+
+func (c *ShardedCache[V]) Size() int {
+	var total int
+	for _, shard := range c.shards {
+		total += shard.Size()
+	}
+	return total
+}
@@ -0,0 +1,190 @@
+package lru
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestNextPow2(t *testing.T) {
+	cases := map[int]int{1: 1, 2: 2, 3: 4, 4: 4, 5: 8, 16: 16, 17: 32}
+	for n, want := range cases {
+		if got := nextPow2(n); got != want {
+			t.Errorf("nextPow2(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestShardedCache_SetGet(t *testing.T) {
+	c := NewSharded[string](100, 4)
+	c.Set("a", "1")
+	c.Set("b", "2")
+
+	if v, ok := c.Get("a"); !ok || v != "1" {
+		t.Errorf("Get(%q) = (%q, %v), want (\"1\", true)", "a", v, ok)
+	}
+	if !c.Has("b") {
+		t.Errorf("Has(%q) = false, want true", "b")
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Errorf("Get(%q) = ok, want !ok", "missing")
+	}
+}
+
+func TestShardedCache_Remove(t *testing.T) {
+	c := NewSharded[string](100, 4)
+	c.Set("a", "1")
+
+	if !c.Remove("a") {
+		t.Fatalf("Remove(%q) = false, want true", "a")
+	}
+	if c.Has("a") {
+		t.Errorf("key %q still present after Remove", "a")
+	}
+	if c.Remove("a") {
+		t.Errorf("Remove(%q) = true for an already-removed key", "a")
+	}
+}
+
+func TestShardedCache_SizeAggregatesShards(t *testing.T) {
+	c := NewSharded[int](100, 8)
+	for i := 0; i < 20; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	if got := c.Size(); got != 20 {
+		t.Errorf("Size() = %d, want 20", got)
+	}
+}
+
+func TestShardedCache_ShardCountRoundsUpToPow2(t *testing.T) {
+	c := NewSharded[int](100, 5)
+	if got := len(c.shards); got != 8 {
+		t.Errorf("len(shards) = %d, want 8 (nextPow2(5))", got)
+	}
+}
+
+func TestShardedCache_DefaultShardCount(t *testing.T) {
+	c := NewSharded[int](100, 0)
+	if len(c.shards) == 0 || len(c.shards)&(len(c.shards)-1) != 0 {
+		t.Errorf("len(shards) = %d, want a positive power of two", len(c.shards))
+	}
+}
+
+func TestShardedCache_ConcurrentAccess(t *testing.T) {
+	c := NewSharded[int](1000, 8)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa(g*1000 + i)
+				c.Set(key, i)
+				c.Get(key)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func BenchmarkCache_SetParallel(b *testing.B) {
+	for _, capacity := range []int{10, 100, 1000, 1e6, 1e9} {
+		for _, keyFactor := range []float64{0.1, 0.5, 1, 2, 10} {
+			keySpace := int(math.Floor(float64(capacity) * keyFactor))
+			if keySpace == 0 {
+				keySpace = 1
+			}
+			b.Run(fmt.Sprintf("cap=%d,keyspace=%d", capacity, keySpace), func(b *testing.B) {
+				c := New[string, int](capacity)
+				b.ResetTimer()
+
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						key := strconv.Itoa(rand.Intn(keySpace))
+						c.Set(key, i)
+						i++
+					}
+				})
+			})
+		}
+	}
+}
+
+func BenchmarkShardedCache_Set(b *testing.B) {
+	for _, capacity := range []int{10, 100, 1000, 1e6, 1e9} {
+		for _, keyFactor := range []float64{0.1, 0.5, 1, 2, 10} {
+			keySpace := int(math.Floor(float64(capacity) * keyFactor))
+			if keySpace == 0 {
+				keySpace = 1
+			}
+			b.Run(fmt.Sprintf("cap=%d,keyspace=%d", capacity, keySpace), func(b *testing.B) {
+				c := NewSharded[int](capacity, 0)
+				b.ResetTimer()
+
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						key := strconv.Itoa(rand.Intn(keySpace))
+						c.Set(key, i)
+						i++
+					}
+				})
+			})
+		}
+	}
+}
+
+func BenchmarkCache_GetParallel(b *testing.B) {
+	for _, capacity := range []int{10, 100, 1000, 1e6, 1e9} {
+		for _, keyFactor := range []float64{0.1, 0.5, 1, 2, 10} {
+			keySpace := int(math.Floor(float64(capacity) * keyFactor))
+			if keySpace == 0 {
+				keySpace = 1
+			}
+			b.Run(fmt.Sprintf("cap=%d,keyspace=%d", capacity, keySpace), func(b *testing.B) {
+				c := New[string, int](capacity)
+				for i := 0; i < keySpace; i++ {
+					c.Set(strconv.Itoa(i), i)
+				}
+				b.ResetTimer()
+
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						key := strconv.Itoa(rand.Intn(keySpace))
+						c.Get(key)
+					}
+				})
+			})
+		}
+	}
+}
+
+func BenchmarkShardedCache_Get(b *testing.B) {
+	for _, capacity := range []int{10, 100, 1000, 1e6, 1e9} {
+		for _, keyFactor := range []float64{0.1, 0.5, 1, 2, 10} {
+			keySpace := int(math.Floor(float64(capacity) * keyFactor))
+			if keySpace == 0 {
+				keySpace = 1
+			}
+			b.Run(fmt.Sprintf("cap=%d,keyspace=%d", capacity, keySpace), func(b *testing.B) {
+				c := NewSharded[int](capacity, 0)
+				for i := 0; i < keySpace; i++ {
+					c.Set(strconv.Itoa(i), i)
+				}
+				b.ResetTimer()
+
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						key := strconv.Itoa(rand.Intn(keySpace))
+						c.Get(key)
+					}
+				})
+			})
+		}
+	}
+}
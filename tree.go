@@ -0,0 +1,192 @@
+package lru
+
+import "sync"
+
+// treeNode is an entry in a Tree's global LRU list: unlike policy/lru's
+// node, it carries its own value (so Tree doesn't need a separate values
+// map) and knows which namespace it belongs to (so evictLocked can drop it
+// from that namespace's map when it falls off the tail of the list).
+type treeNode[K comparable, V any] struct {
+	ns         uint64
+	key        K
+	value      V
+	next, prev *treeNode[K, V]
+}
+
+// Tree is a collection of namespaces that share one global LRU capacity:
+// a Set in one namespace can evict the least-recently-used entry from any
+// other, rather than each namespace getting a static slice of the budget.
+// See NewTree.
+type Tree[K comparable, V any] struct {
+	mu sync.Mutex
+
+	cap        int
+	count      int
+	head, tail *treeNode[K, V]
+
+	// namespaces[id] indexes that namespace's own entries for O(1)
+	// Get/Has/Remove and for DeleteNamespace to unlink them in one pass,
+	// without touching any other namespace's entries.
+	namespaces map[uint64]map[K]*treeNode[K, V]
+}
+
+// NewTree returns a Tree whose namespaces together hold at most cap entries.
+func NewTree[K comparable, V any](cap int) *Tree[K, V] {
+	return &Tree[K, V]{
+		cap:        cap,
+		namespaces: make(map[uint64]map[K]*treeNode[K, V]),
+	}
+}
+
+// Namespace returns a handle scoped to id. Namespaces need not be created
+// up front: an id is implicitly created on its first Set and implicitly
+// forgotten once empty.
+func (t *Tree[K, V]) Namespace(id uint64) *Namespace[K, V] {
+	return &Namespace[K, V]{tree: t, id: id}
+}
+
+// Size returns the total number of entries across every namespace.
+func (t *Tree[K, V]) Size() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.count
+}
+
+// DeleteNamespace removes every entry belonging to id, unlinking each from
+// the global LRU list in one pass.
+func (t *Tree[K, V]) DeleteNamespace(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, n := range t.namespaces[id] {
+		t.unlink(n)
+		t.count--
+	}
+	delete(t.namespaces, id)
+}
+
+// Namespace is a Cache-like handle onto one logical partition of a Tree: it
+// shares the Tree's global capacity with every other namespace.
+type Namespace[K comparable, V any] struct {
+	tree *Tree[K, V]
+	id   uint64
+}
+
+func (ns *Namespace[K, V]) Set(key K, value V) {
+	t := ns.tree
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	nodes := t.namespaces[ns.id]
+	if nodes == nil {
+		nodes = make(map[K]*treeNode[K, V])
+		t.namespaces[ns.id] = nodes
+	}
+
+	if n, existed := nodes[key]; existed {
+		n.value = value
+		t.moveFront(n)
+		return
+	}
+
+	n := &treeNode[K, V]{ns: ns.id, key: key, value: value}
+	nodes[key] = n
+	t.moveFront(n)
+	t.count++
+
+	if t.count > t.cap {
+		t.evictLocked()
+	}
+}
+
+func (ns *Namespace[K, V]) Get(key K) (V, bool) {
+	t := ns.tree
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n, ok := t.namespaces[ns.id][key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	t.moveFront(n)
+	return n.value, true
+}
+
+func (ns *Namespace[K, V]) Has(key K) bool {
+	t := ns.tree
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.namespaces[ns.id][key]
+	return ok
+}
+
+func (ns *Namespace[K, V]) Remove(key K) bool {
+	t := ns.tree
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	nodes := t.namespaces[ns.id]
+	n, ok := nodes[key]
+	if !ok {
+		return false
+	}
+	t.unlink(n)
+	delete(nodes, key)
+	if len(nodes) == 0 {
+		delete(t.namespaces, ns.id)
+	}
+	t.count--
+	return true
+}
+
+// evictLocked drops the least-recently-used entry across the whole tree,
+// regardless of which namespace it belongs to.
+func (t *Tree[K, V]) evictLocked() {
+	if t.tail == nil {
+		return
+	}
+	victim := t.tail
+	t.unlink(victim)
+
+	nodes := t.namespaces[victim.ns]
+	delete(nodes, victim.key)
+	if len(nodes) == 0 {
+		delete(t.namespaces, victim.ns)
+	}
+	t.count--
+}
+
+func (t *Tree[K, V]) moveFront(n *treeNode[K, V]) {
+	if n == t.head {
+		return
+	}
+	t.unlink(n)
+
+	n.prev = nil
+	n.next = t.head
+	if t.head != nil {
+		t.head.prev = n
+	}
+	t.head = n
+	if t.tail == nil {
+		t.tail = n
+	}
+}
+
+func (t *Tree[K, V]) unlink(n *treeNode[K, V]) {
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+	if n.prev != nil {
+		n.prev.next = n.next
+	}
+	if n == t.head {
+		t.head = n.next
+	}
+	if n == t.tail {
+		t.tail = n.prev
+	}
+	n.next, n.prev = nil, nil
+}
@@ -0,0 +1,120 @@
+package lru
+
+import "testing"
+
+func TestTree_NamespacesAreIsolated(t *testing.T) {
+	tree := NewTree[string, int](10)
+	a := tree.Namespace(1)
+	b := tree.Namespace(2)
+
+	a.Set("key", 1)
+	b.Set("key", 2)
+
+	if v, ok := a.Get("key"); !ok || v != 1 {
+		t.Errorf(`namespace 1 Get("key") = (%d, %v), want (1, true)`, v, ok)
+	}
+	if v, ok := b.Get("key"); !ok || v != 2 {
+		t.Errorf(`namespace 2 Get("key") = (%d, %v), want (2, true)`, v, ok)
+	}
+}
+
+func TestTree_SharesGlobalCapacity(t *testing.T) {
+	tree := NewTree[string, int](2)
+	a := tree.Namespace(1)
+	b := tree.Namespace(2)
+
+	a.Set("a1", 1)
+	b.Set("b1", 2)
+	b.Set("b2", 3) // over the global cap of 2; evicts a1, the LRU entry tree-wide
+
+	if a.Has("a1") {
+		t.Errorf("expected a1 to be evicted to make room for another namespace's entry")
+	}
+	if !b.Has("b1") || !b.Has("b2") {
+		t.Errorf("expected both of namespace 2's entries to survive")
+	}
+	if got := tree.Size(); got != 2 {
+		t.Errorf("Size() = %d, want 2", got)
+	}
+}
+
+func TestTree_GetTouchesGlobalRecency(t *testing.T) {
+	tree := NewTree[string, int](2)
+	a := tree.Namespace(1)
+	b := tree.Namespace(2)
+
+	a.Set("a1", 1)
+	b.Set("b1", 2)
+	a.Get("a1") // a1 is now more recently used than b1
+
+	b.Set("b2", 3) // evicts b1, not a1
+
+	if !a.Has("a1") {
+		t.Errorf("expected a1 to survive after being touched")
+	}
+	if b.Has("b1") {
+		t.Errorf("expected b1 to be evicted as the tree-wide LRU entry")
+	}
+}
+
+func TestTree_Remove(t *testing.T) {
+	tree := NewTree[string, int](10)
+	ns := tree.Namespace(1)
+	ns.Set("a", 1)
+
+	if !ns.Remove("a") {
+		t.Fatalf("Remove(%q) = false, want true", "a")
+	}
+	if ns.Has("a") {
+		t.Errorf("key %q still present after Remove", "a")
+	}
+	if ns.Remove("a") {
+		t.Errorf("Remove(%q) = true for an already-removed key", "a")
+	}
+}
+
+func TestTree_RemoveForgetsEmptyNamespace(t *testing.T) {
+	tree := NewTree[string, int](10)
+	ns := tree.Namespace(1)
+	ns.Set("a", 1)
+	ns.Remove("a")
+
+	if _, ok := tree.namespaces[1]; ok {
+		t.Errorf("expected namespace 1 to be forgotten once its last entry was removed")
+	}
+}
+
+func TestTree_EvictionForgetsEmptyNamespace(t *testing.T) {
+	tree := NewTree[string, int](1)
+	a := tree.Namespace(1)
+	b := tree.Namespace(2)
+
+	a.Set("a1", 1)
+	b.Set("b1", 2) // evicts a1, a's only entry
+
+	if _, ok := tree.namespaces[1]; ok {
+		t.Errorf("expected namespace 1 to be forgotten once its last entry was evicted")
+	}
+}
+
+func TestTree_DeleteNamespace(t *testing.T) {
+	tree := NewTree[string, int](10)
+	a := tree.Namespace(1)
+	b := tree.Namespace(2)
+
+	a.Set("a1", 1)
+	a.Set("a2", 2)
+	b.Set("b1", 3)
+
+	tree.DeleteNamespace(1)
+
+	if a.Has("a1") || a.Has("a2") {
+		t.Errorf("expected namespace 1's entries to be gone after DeleteNamespace")
+	}
+	if !b.Has("b1") {
+		t.Errorf("expected namespace 2's entries to survive DeleteNamespace(1)")
+	}
+	if got := tree.Size(); got != 1 {
+		t.Errorf("Size() = %d, want 1", got)
+	}
+}